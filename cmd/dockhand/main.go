@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"html/template"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	dockerc "dockhand/internal/docker"
-	"dockhand/internal/web"
+	dockerc "github.com/you/dockhand/internal/docker"
+	"github.com/you/dockhand/internal/web"
 )
 
 func main() {
@@ -15,6 +20,7 @@ func main() {
 	host := getenv("DOCKER_HOST", "unix:///var/run/docker.sock")
 	addr := getenv("ADDR", ":8088")
 	grafanaURL := os.Getenv("GRAFANA_URL") // optional
+	grace := getenvDuration("SHUTDOWN_GRACE", 10*time.Second)
 
 	dc, err := dockerc.New(host)
 	if err != nil {
@@ -27,14 +33,83 @@ func main() {
 			"internal/ui/templates/row.tmpl.html",
 			"internal/ui/templates/index.tmpl.html",
 			"internal/ui/templates/logs.tmpl.html",
+			"internal/ui/templates/create.tmpl.html",
+			"internal/ui/templates/exec.tmpl.html",
 		))
 
-	srv := web.New(dc, tpl, grafanaURL)
+	// rootCtx governs background work and in-flight SSE/exec streams; it is
+	// cancelled as soon as a shutdown signal arrives, independently of the
+	// grace period given to http.Server.Shutdown.
+	rootCtx, cancel := context.WithCancel(context.Background())
+
+	srv := web.New(rootCtx, dc, tpl, grafanaURL)
 	mux := http.NewServeMux()
 	srv.Routes(mux)
 
-	log.Printf("Dockhand listening on %s (DOCKER_HOST=%s)", addr, host)
-	log.Fatal(http.ListenAndServe(addr, mux))
+	httpServer := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+		BaseContext: func(net.Listener) context.Context {
+			return rootCtx
+		},
+	}
+
+	go func() {
+		log.Printf("Dockhand listening on %s (DOCKER_HOST=%s)", addr, host)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	shutdown(httpServer, cancel, dc, grace)
+}
+
+// shutdown blocks until SIGINT/SIGTERM, then drains the server the same way
+// Docker's own signal trap does: the first signal starts a graceful shutdown
+// within grace; a second signal during that window force-closes idle
+// connections; a third exits immediately without waiting for anything.
+func shutdown(httpServer *http.Server, cancel context.CancelFunc, dc *dockerc.Client, grace time.Duration) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	<-sigCh
+	log.Printf("shutdown: signal received, draining for up to %s", grace)
+	cancel() // unblocks ContainerLogs/Events/Stats readers in active SSE and exec handlers
+
+	done := make(chan struct{})
+	go func() {
+		ctx, timeoutCancel := context.WithTimeout(context.Background(), grace)
+		defer timeoutCancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			log.Printf("shutdown: graceful shutdown error: %v", err)
+		}
+		close(done)
+	}()
+
+	graceTimer := time.NewTimer(grace)
+	defer graceTimer.Stop()
+
+	select {
+	case <-done:
+	case <-graceTimer.C:
+		log.Printf("shutdown: grace period elapsed, force-closing idle connections")
+		httpServer.Close()
+		<-done
+	case <-sigCh:
+		log.Printf("shutdown: second signal, force-closing idle connections")
+		httpServer.Close()
+		select {
+		case <-done:
+		case <-sigCh:
+			log.Printf("shutdown: third signal, exiting immediately")
+			os.Exit(1)
+		}
+	}
+
+	if err := dc.Close(); err != nil {
+		log.Printf("shutdown: docker client close error: %v", err)
+	}
+	log.Printf("shutdown complete")
 }
 
 func getenv(k, def string) string {
@@ -43,3 +118,16 @@ func getenv(k, def string) string {
 	}
 	return def
 }
+
+func getenvDuration(k string, def time.Duration) time.Duration {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("invalid %s=%q, using default %s", k, v, def)
+		return def
+	}
+	return d
+}
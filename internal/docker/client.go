@@ -2,9 +2,12 @@ package dockerc
 
 import (
 	"context"
+	"io"
 
 	"github.com/docker/docker/api/types"
 	container "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 )
 
@@ -27,7 +30,54 @@ func New(host string) (*Client, error) {
 	return &Client{cli: cli}, nil
 }
 
+// Close releases the underlying Docker API client's connection.
+func (c *Client) Close() error {
+	return c.cli.Close()
+}
+
 func (c *Client) ListContainers(ctx context.Context, all bool) ([]types.Container, error) {
 	// Note: in newer SDKs, this is container.ListOptions (not types.ContainerListOptions)
 	return c.cli.ContainerList(ctx, container.ListOptions{All: all})
 }
+
+func (c *Client) ContainerStart(ctx context.Context, id string, options types.ContainerStartOptions) error {
+	return c.cli.ContainerStart(ctx, id, options)
+}
+
+func (c *Client) ContainerStop(ctx context.Context, id string, opt container.StopOptions) error {
+	return c.cli.ContainerStop(ctx, id, opt)
+}
+
+func (c *Client) ContainerRestart(ctx context.Context, id string, opt container.StopOptions) error {
+	return c.cli.ContainerRestart(ctx, id, opt)
+}
+
+// ContainerLogs wraps cli.ContainerLogs.
+func (c *Client) ContainerLogs(ctx context.Context, id string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	return c.cli.ContainerLogs(ctx, id, options)
+}
+
+// ContainerStats wraps cli.ContainerStats. When stream is true the returned
+// body is a never-ending sequence of JSON stats frames (one per second) that
+// the caller must decode and close; when false it is a single frame.
+func (c *Client) ContainerStats(ctx context.Context, id string, stream bool) (io.ReadCloser, error) {
+	resp, err := c.cli.ContainerStats(ctx, id, stream)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Events subscribes to the Docker daemon's event stream, scoped to container
+// lifecycle events. When ctx is cancelled or the stream fails, the error is
+// sent on the error channel and that channel is closed; the message channel
+// is never closed, so callers select on both rather than range over messages.
+func (c *Client) Events(ctx context.Context) (<-chan events.Message, <-chan error) {
+	f := filters.NewArgs(
+		filters.Arg("type", "container"),
+	)
+	for _, action := range []string{"create", "start", "die", "stop", "destroy", "health_status"} {
+		f.Add("event", action)
+	}
+	return c.cli.Events(ctx, types.EventsOptions{Filters: f})
+}
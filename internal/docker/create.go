@@ -0,0 +1,159 @@
+package dockerc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	container "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/go-connections/nat"
+)
+
+// PortMapping binds a host port to a container port, e.g. "8080" -> "80/tcp".
+type PortMapping struct {
+	HostPort      string
+	ContainerPort string
+	Proto         string // "tcp" or "udp", defaults to "tcp"
+}
+
+// VolumeMapping bind-mounts a host path into the container.
+type VolumeMapping struct {
+	HostPath      string
+	ContainerPath string
+}
+
+// CreateSpec is the subset of container.Config/HostConfig/NetworkingConfig
+// that the create form exposes.
+type CreateSpec struct {
+	Image         string
+	Name          string
+	Env           []string
+	Cmd           []string
+	Ports         []PortMapping
+	Volumes       []VolumeMapping
+	RestartPolicy string // "no", "always", "unless-stopped", "on-failure"
+}
+
+// CreateContainer maps spec onto the SDK's Config/HostConfig/NetworkingConfig
+// and creates (but does not start) the container, returning its ID.
+func (c *Client) CreateContainer(ctx context.Context, spec CreateSpec) (string, error) {
+	exposedPorts, portBindings, err := spec.portArgs()
+	if err != nil {
+		return "", err
+	}
+
+	binds := make([]string, 0, len(spec.Volumes))
+	for _, v := range spec.Volumes {
+		binds = append(binds, fmt.Sprintf("%s:%s", v.HostPath, v.ContainerPath))
+	}
+
+	cfg := &container.Config{
+		Image:        spec.Image,
+		Env:          spec.Env,
+		Cmd:          spec.Cmd,
+		ExposedPorts: exposedPorts,
+	}
+	hostCfg := &container.HostConfig{
+		Binds:        binds,
+		PortBindings: portBindings,
+		RestartPolicy: container.RestartPolicy{
+			Name: spec.RestartPolicy,
+		},
+	}
+
+	resp, err := c.cli.ContainerCreate(ctx, cfg, hostCfg, &network.NetworkingConfig{}, nil, spec.Name)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (spec CreateSpec) portArgs() (nat.PortSet, nat.PortMap, error) {
+	exposed := make(nat.PortSet, len(spec.Ports))
+	bindings := make(nat.PortMap, len(spec.Ports))
+	for _, p := range spec.Ports {
+		proto := p.Proto
+		if proto == "" {
+			proto = "tcp"
+		}
+		port, err := nat.NewPort(proto, p.ContainerPort)
+		if err != nil {
+			return nil, nil, fmt.Errorf("port %s/%s: %w", p.ContainerPort, proto, err)
+		}
+		exposed[port] = struct{}{}
+		bindings[port] = append(bindings[port], nat.PortBinding{HostPort: p.HostPort})
+	}
+	return exposed, bindings, nil
+}
+
+// PullImage pulls ref, streaming the daemon's JSONMessage progress frames to
+// the caller. authConfig is the base64-encoded X-Registry-Auth header value;
+// pass "" for public images.
+func (c *Client) PullImage(ctx context.Context, ref, authConfig string) (io.ReadCloser, error) {
+	return c.cli.ImagePull(ctx, ref, types.ImagePullOptions{RegistryAuth: authConfig})
+}
+
+// RegistryAuth looks up credentials for ref's registry in ~/.docker/config.json
+// and returns the encoded auth header value, or "" if none is configured
+// (public images pull fine with no header).
+func RegistryAuth(ref string) (string, error) {
+	registryHost := registryHostFor(ref)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".docker", "config.json"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var cfg struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", err
+	}
+	entry, ok := cfg.Auths[registryHost]
+	if !ok {
+		return "", nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return "", err
+	}
+	user, pass, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", nil
+	}
+
+	return registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      user,
+		Password:      pass,
+		ServerAddress: registryHost,
+	})
+}
+
+// registryHostFor extracts the registry host from an image reference,
+// defaulting to Docker Hub for unqualified references (e.g. "nginx:latest").
+func registryHostFor(ref string) string {
+	name, _, _ := strings.Cut(ref, "@")
+	first, _, ok := strings.Cut(name, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first
+	}
+	return "https://index.docker.io/v1/"
+}
@@ -0,0 +1,44 @@
+package dockerc
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+)
+
+// Exec creates and attaches to an exec session running cmd inside the
+// container, returning the hijacked stdio connection and the exec ID (needed
+// later for resize and inspect calls).
+func (c *Client) Exec(ctx context.Context, id string, cmd []string, tty bool) (types.HijackedResponse, string, error) {
+	created, err := c.cli.ContainerExecCreate(ctx, id, types.ExecConfig{
+		Cmd:          cmd,
+		Tty:          tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return types.HijackedResponse{}, "", err
+	}
+
+	hijacked, err := c.cli.ContainerExecAttach(ctx, created.ID, types.ExecStartCheck{Tty: tty})
+	if err != nil {
+		return types.HijackedResponse{}, "", err
+	}
+	return hijacked, created.ID, nil
+}
+
+// ExecResize resizes the TTY of a running exec session.
+func (c *Client) ExecResize(ctx context.Context, execID string, height, width uint) error {
+	return c.cli.ContainerExecResize(ctx, execID, types.ResizeOptions{Height: height, Width: width})
+}
+
+// ExecInspect reports whether the exec session is still running and, once
+// finished, its exit code.
+func (c *Client) ExecInspect(ctx context.Context, execID string) (running bool, exitCode int, err error) {
+	info, err := c.cli.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return false, 0, err
+	}
+	return info.Running, info.ExitCode, nil
+}
@@ -0,0 +1,322 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/you/dockhand/internal/docker"
+)
+
+// createPage serves the create-container form.
+func (s *Server) createPage(w http.ResponseWriter, r *http.Request) {
+	if err := s.templates.ExecuteTemplate(w, "create", nil); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// createRunPage starts the pull+create+start job on POST (the only request
+// method that may trigger it) and serves the progress page that tails it on
+// GET. Splitting the two by method keeps the mutation out of reach of
+// prefetching, crawlers, and anything else capable of issuing a bare GET.
+func (s *Server) createRunPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		spec := specFromQuery(r.PostForm)
+		id := s.createJobs.start(s.rootCtx, s.dc, spec)
+		http.Redirect(w, r, "/containers/create/run?job="+id, http.StatusSeeOther)
+		return
+	}
+
+	data := map[string]any{"JobID": r.URL.Query().Get("job")}
+	if err := s.templates.ExecuteTemplate(w, "create-run", data); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// specFromQuery maps the create form's fields onto a dockerc.CreateSpec.
+func specFromQuery(q map[string][]string) dockerc.CreateSpec {
+	get := func(k string) string {
+		if v := q[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	lines := func(k string) []string {
+		var out []string
+		sc := bufio.NewScanner(strings.NewReader(get(k)))
+		for sc.Scan() {
+			if line := strings.TrimSpace(sc.Text()); line != "" {
+				out = append(out, line)
+			}
+		}
+		return out
+	}
+
+	spec := dockerc.CreateSpec{
+		Image:         get("image"),
+		Name:          get("name"),
+		Env:           lines("env"),
+		RestartPolicy: get("restart_policy"),
+	}
+	if cmd := strings.TrimSpace(get("cmd")); cmd != "" {
+		spec.Cmd = strings.Fields(cmd)
+	}
+	for _, p := range lines("ports") {
+		host, rest, ok := strings.Cut(p, ":")
+		if !ok {
+			continue
+		}
+		containerPort, proto, _ := strings.Cut(rest, "/")
+		spec.Ports = append(spec.Ports, dockerc.PortMapping{HostPort: host, ContainerPort: containerPort, Proto: proto})
+	}
+	for _, v := range lines("volumes") {
+		host, containerPath, ok := strings.Cut(v, ":")
+		if !ok {
+			continue
+		}
+		spec.Volumes = append(spec.Volumes, dockerc.VolumeMapping{HostPath: host, ContainerPath: containerPath})
+	}
+	return spec
+}
+
+// createStreamSSE tails a job started by createRunPage's POST handler: it
+// replays whatever progress already happened before this client connected,
+// then streams further events live until the job finishes. It never itself
+// pulls, creates, or starts anything.
+func (s *Server) createStreamSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", 500)
+		return
+	}
+
+	job, ok := s.createJobs.get(r.URL.Query().Get("job"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch, backlog, done := job.subscribe()
+	defer job.unsubscribe(ch)
+
+	for _, ev := range backlog {
+		writeSSEEvent(w, flusher, ev.event, ev.data)
+	}
+	if done {
+		return
+	}
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeSSEEvent(w, flusher, ev.event, ev.data)
+		}
+	}
+}
+
+// writeSSEEvent writes a single SSE frame, running data through sseEscaper
+// so embedded newlines (common in Docker status text and error messages)
+// can't corrupt the frame for the rest of the stream. event may be "" for
+// the default "message" event.
+func writeSSEEvent(w http.ResponseWriter, flusher http.Flusher, event, data string) {
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	fmt.Fprint(w, "data: ")
+	sseEscaper{w}.Write([]byte(data))
+	fmt.Fprint(w, "\n\n")
+	flusher.Flush()
+}
+
+// createEvent is one SSE frame recorded by a createJob, replayed verbatim to
+// any client that subscribes after it was published.
+type createEvent struct {
+	event string
+	data  string
+}
+
+// createJob runs one pull+create+start job to completion and fans out its
+// progress to any number of SSE clients, including ones that connect after
+// the job has already produced some events (the browser's redirect from the
+// POST to the GET progress page is never instant).
+type createJob struct {
+	mu      sync.Mutex
+	history []createEvent
+	done    bool
+	clients map[chan createEvent]struct{}
+}
+
+// subscribe returns a channel for live events plus everything already
+// published, and whether the job has already finished (in which case the
+// backlog is the whole story and the caller shouldn't wait on the channel).
+func (j *createJob) subscribe() (chan createEvent, []createEvent, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ch := make(chan createEvent, clientBuf)
+	j.clients[ch] = struct{}{}
+	backlog := append([]createEvent(nil), j.history...)
+	return ch, backlog, j.done
+}
+
+func (j *createJob) unsubscribe(ch chan createEvent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.clients[ch]; ok {
+		delete(j.clients, ch)
+		close(ch)
+	}
+}
+
+func (j *createJob) publish(ev createEvent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history = append(j.history, ev)
+	for ch := range j.clients {
+		select {
+		case ch <- ev:
+		default:
+			// slow consumer: it already has the full history on unsubscribe/resubscribe
+		}
+	}
+}
+
+func (j *createJob) finish() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.done = true
+	for ch := range j.clients {
+		close(ch)
+	}
+	j.clients = map[chan createEvent]struct{}{}
+}
+
+// createJobStore holds every create job kicked off by POSTs to
+// /containers/create/run, in flight or finished, keyed by an opaque ID handed
+// to the client in the redirect so the progress page knows what to tail.
+// Finished jobs are never removed.
+type createJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*createJob
+}
+
+func newCreateJobStore() *createJobStore {
+	return &createJobStore{jobs: make(map[string]*createJob)}
+}
+
+// start registers a new job and runs it in the background on ctx (the
+// server's root context, not the request's: the POST returns as soon as the
+// job is registered, long before the pull finishes).
+func (s *createJobStore) start(ctx context.Context, dc *dockerc.Client, spec dockerc.CreateSpec) string {
+	job := &createJob{clients: make(map[chan createEvent]struct{})}
+	id := newJobID()
+
+	s.mu.Lock()
+	s.jobs[id] = job
+	s.mu.Unlock()
+
+	go job.run(ctx, dc, spec)
+	return id
+}
+
+func (s *createJobStore) get(id string) (*createJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+// newJobID returns an opaque, unguessable job identifier. It's exposed to the
+// client in a URL, so it must not be predictable the way a simple counter
+// would be.
+func newJobID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err) // crypto/rand failing means the system entropy source is broken
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// run pulls spec.Image (streaming layer progress), creates the container,
+// and starts it, publishing each step so subscribers can follow along.
+func (j *createJob) run(ctx context.Context, dc *dockerc.Client, spec dockerc.CreateSpec) {
+	defer j.finish()
+
+	auth, err := dockerc.RegistryAuth(spec.Image)
+	if err != nil {
+		log.Printf("create: registry auth: %v", err)
+	}
+
+	rc, err := dc.PullImage(ctx, spec.Image, auth)
+	if err != nil {
+		j.publish(createEvent{"error-detail", err.Error()})
+		return
+	}
+	defer rc.Close()
+
+	dec := json.NewDecoder(rc)
+	for {
+		var msg types.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			break
+		}
+		j.publish(createEvent{"", pullProgressLine(msg)})
+	}
+
+	id, err := dc.CreateContainer(ctx, spec)
+	if err != nil {
+		j.publish(createEvent{"error-detail", err.Error()})
+		return
+	}
+	if err := dc.ContainerStart(ctx, id, types.ContainerStartOptions{}); err != nil {
+		j.publish(createEvent{"error-detail", err.Error()})
+		return
+	}
+
+	j.publish(createEvent{"done", id})
+}
+
+// pullProgressLine renders one JSONMessage progress frame as a single
+// human-readable line, e.g. "a1b2c3d4: Downloading [====>     ] 40%".
+func pullProgressLine(msg types.JSONMessage) string {
+	if msg.Error != nil {
+		return fmt.Sprintf("%s: error: %s", msg.ID, msg.Error.Message)
+	}
+	if msg.Progress == nil || msg.Progress.Total == 0 {
+		return fmt.Sprintf("%s: %s", msg.ID, msg.Status)
+	}
+	pct := 100 * msg.Progress.Current / msg.Progress.Total
+	if pct < 0 {
+		pct = 0
+	} else if pct > 100 {
+		pct = 100
+	}
+	const barWidth = 10
+	filled := int(pct) * barWidth / 100
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+	return fmt.Sprintf("%s: %s [%s] %d%%", msg.ID, msg.Status, bar, pct)
+}
@@ -0,0 +1,146 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/you/dockhand/internal/docker"
+)
+
+// clientBuf is the size of each subscriber's buffered channel. A client that
+// falls behind by more than this many events has events dropped rather than
+// blocking the fan-out goroutine.
+const clientBuf = 16
+
+// eventHub maintains the single shared subscription to the Docker daemon's
+// event stream and fans events out to any number of SSE clients.
+type eventHub struct {
+	mu      sync.Mutex
+	clients map[chan events.Message]struct{}
+}
+
+func newEventHub(ctx context.Context, dc *dockerc.Client) *eventHub {
+	h := &eventHub{clients: make(map[chan events.Message]struct{})}
+	go h.run(ctx, dc)
+	return h
+}
+
+func (h *eventHub) run(ctx context.Context, dc *dockerc.Client) {
+	msgs, errs := dc.Events(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			if err != nil {
+				log.Printf("docker events: %v", err)
+			}
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			h.broadcast(msg)
+		}
+	}
+}
+
+func (h *eventHub) broadcast(msg events.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- msg:
+		default:
+			// slow consumer: drop this event rather than block the fan-out loop
+		}
+	}
+}
+
+func (h *eventHub) subscribe() chan events.Message {
+	ch := make(chan events.Message, clientBuf)
+	h.mu.Lock()
+	h.clients[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan events.Message) {
+	h.mu.Lock()
+	delete(h.clients, ch)
+	h.mu.Unlock()
+	close(ch)
+}
+
+// eventsStreamSSE fans out container lifecycle events so the index page can
+// swap the affected row in place instead of polling /partials/rows.
+func (s *Server) eventsStreamSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", 500)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			s.renderRowEvent(w, msg)
+			flusher.Flush()
+		}
+	}
+}
+
+// renderRowEvent re-renders the row for msg's container and pushes it as an
+// hx-sse "message" event so the client swaps the matching <tr> in place.
+func (s *Server) renderRowEvent(w http.ResponseWriter, msg events.Message) {
+	cs, err := s.dc.ListContainers(context.Background(), true)
+	if err != nil {
+		log.Printf("events: list containers: %v", err)
+		return
+	}
+	for _, c := range cs {
+		if c.ID == msg.Actor.ID {
+			fmt.Fprint(w, "data: ")
+			if err := s.templates.ExecuteTemplate(sseEscaper{w}, "row-oob", c); err != nil {
+				log.Printf("events: render row: %v", err)
+				return
+			}
+			fmt.Fprint(w, "\n\n")
+			return
+		}
+	}
+	// container no longer listed (destroy): an empty oob "delete" swap removes the row
+	fmt.Fprintf(w, "data: <div hx-swap-oob=\"delete:#c-%s\"></div>\n\n", msg.Actor.ID)
+}
+
+// sseEscaper strips newlines from template output so a multi-line row render
+// can't break the SSE "data:" framing.
+type sseEscaper struct{ w http.ResponseWriter }
+
+func (e sseEscaper) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b == '\n' {
+			continue
+		}
+		if _, err := e.w.Write([]byte{b}); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
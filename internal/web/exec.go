@@ -0,0 +1,160 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var execUpgrader = websocket.Upgrader{
+	CheckOrigin: sameOrigin,
+}
+
+// sameOrigin restricts the exec WebSocket to same-origin requests: an exec
+// session is an interactive shell into a container with no auth of its own,
+// so any other page the operator's browser has open must not be able to
+// open one cross-origin.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// no Origin header: not a browser cross-origin request
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return u.Host == r.Host
+}
+
+// execPage serves the xterm.js terminal page for a container.
+func (s *Server) execPage(w http.ResponseWriter, r *http.Request) {
+	// /exec/{id}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] != "exec" {
+		http.NotFound(w, r)
+		return
+	}
+	data := map[string]any{"ID": parts[1]}
+	if err := s.templates.ExecuteTemplate(w, "exec", data); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// resizeMsg is the control frame the terminal sends to report its size.
+type resizeMsg struct {
+	Type string `json:"type"`
+	Cols uint   `json:"cols"`
+	Rows uint   `json:"rows"`
+}
+
+// execWS upgrades to a WebSocket and bridges it to an interactive exec
+// session inside the container.
+func (s *Server) execWS(w http.ResponseWriter, r *http.Request) {
+	// /exec/ws/{id}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "exec" || parts[1] != "ws" {
+		http.NotFound(w, r)
+		return
+	}
+	id := parts[2]
+	ctx := r.Context()
+
+	conn, err := execUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("exec: upgrade: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	hijacked, execID, err := s.dc.Exec(ctx, id, []string{"/bin/sh"}, true)
+	if err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("exec create failed: "+err.Error()))
+		return
+	}
+	// hijacked is closed as soon as either side of the bridge exits, not
+	// merely when the handler returns: otherwise a client that disconnects
+	// while the remote process is still running never unblocks the reader
+	// goroutine below, and both it and the exec session leak.
+	var closeHijacked sync.Once
+	closeFn := func() { closeHijacked.Do(func() { hijacked.Close() }) }
+	defer closeFn()
+
+	done := make(chan struct{})
+	go s.execWatchExit(ctx, execID, conn, done)
+
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		for {
+			n, err := hijacked.Reader.Read(buf)
+			if n > 0 {
+				if err := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		if msgType == websocket.TextMessage {
+			var resize resizeMsg
+			if err := json.Unmarshal(data, &resize); err == nil && resize.Type == "resize" {
+				if err := s.dc.ExecResize(ctx, execID, resize.Rows, resize.Cols); err != nil {
+					log.Printf("exec: resize: %v", err)
+				}
+				continue
+			}
+		}
+		if _, err := hijacked.Conn.Write(data); err != nil {
+			break
+		}
+	}
+
+	// the client side of the bridge is gone; close the exec connection so
+	// the reader goroutine above unblocks instead of waiting on a process
+	// that may run indefinitely.
+	closeFn()
+	<-done
+}
+
+// execWatchExit polls the exec session until it finishes, then closes the
+// WebSocket with the exit code so the browser can report it.
+func (s *Server) execWatchExit(ctx context.Context, execID string, conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			running, exitCode, err := s.dc.ExecInspect(ctx, execID)
+			if err != nil {
+				log.Printf("exec: inspect: %v", err)
+				return
+			}
+			if !running {
+				closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "exit code "+strconv.Itoa(exitCode))
+				conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+				return
+			}
+		}
+	}
+}
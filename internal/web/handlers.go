@@ -7,23 +7,39 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"os"
+	"net/url"
 	"strings"
-	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/you/dockhand/internal/docker"
 )
 
 type Server struct {
+	rootCtx    context.Context
 	dc         *dockerc.Client
 	templates  *template.Template
 	grafanaURL string // optional deep-link to Loki Explore (e.g., http://grafana:3000/explore)
+	stats      *statsStore
+	events     *eventHub
+	createJobs *createJobStore
 }
 
-func New(dc *dockerc.Client, templates *template.Template, grafanaURL string) *Server {
-	return &Server{dc: dc, templates: templates, grafanaURL: grafanaURL}
+// New builds a Server. ctx is the application's root context: it governs
+// background work (the shared Docker events subscription, and any create
+// job kicked off by a POST that outlives that single request) and is
+// cancelled on shutdown.
+func New(ctx context.Context, dc *dockerc.Client, templates *template.Template, grafanaURL string) *Server {
+	return &Server{
+		rootCtx:    ctx,
+		dc:         dc,
+		templates:  templates,
+		grafanaURL: grafanaURL,
+		stats:      newStatsStore(),
+		events:     newEventHub(ctx, dc),
+		createJobs: newCreateJobStore(),
+	}
 }
 
 func (s *Server) Routes(mux *http.ServeMux) {
@@ -32,6 +48,15 @@ func (s *Server) Routes(mux *http.ServeMux) {
 	mux.HandleFunc("/containers/", s.containerAction)
 	mux.HandleFunc("/logs/", s.logsPage)
 	mux.HandleFunc("/logs/stream/", s.logsStreamSSE)
+	mux.HandleFunc("/logs/download/", s.logsDownload)
+	mux.HandleFunc("/stats/stream/", s.statsStreamSSE)
+	mux.HandleFunc("/metrics", s.metrics)
+	mux.HandleFunc("/events/stream", s.eventsStreamSSE)
+	mux.HandleFunc("/containers/create", s.createPage)
+	mux.HandleFunc("/containers/create/run", s.createRunPage)
+	mux.HandleFunc("/containers/create/stream", s.createStreamSSE)
+	mux.HandleFunc("/exec/", s.execPage)
+	mux.HandleFunc("/exec/ws/", s.execWS)
 
 	// static htmx
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("web/static"))))
@@ -79,13 +104,13 @@ func (s *Server) containerAction(w http.ResponseWriter, r *http.Request) {
 	var err error
 	switch action {
 	case "start":
-		err = s.dc.Cli().ContainerStart(ctx, id, types.ContainerStartOptions{})
+		err = s.dc.ContainerStart(ctx, id, types.ContainerStartOptions{})
 	case "stop":
 		timeout := container.StopOptions{Timeout: intPtr(10)}
-		err = s.dc.Cli().ContainerStop(ctx, id, timeout)
+		err = s.dc.ContainerStop(ctx, id, timeout)
 	case "restart":
 		timeout := container.StopOptions{Timeout: intPtr(10)}
-		err = s.dc.Cli().ContainerRestart(ctx, id, timeout)
+		err = s.dc.ContainerRestart(ctx, id, timeout)
 	default:
 		http.NotFound(w, r)
 		return
@@ -97,20 +122,6 @@ func (s *Server) containerAction(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(204) // HTMX: no content
 }
 
-func (c *dockerc.Client) Cli() *clientWrapper { return &clientWrapper{c} }
-
-type clientWrapper struct{ *dockerc.Client }
-
-func (w *clientWrapper) ContainerStart(ctx context.Context, container string, options types.ContainerStartOptions) error {
-	return w.cli.ContainerStart(ctx, container, options)
-}
-func (w *clientWrapper) ContainerStop(ctx context.Context, container string, opt container.StopOptions) error {
-	return w.cli.ContainerStop(ctx, container, opt)
-}
-func (w *clientWrapper) ContainerRestart(ctx context.Context, container string, opt container.StopOptions) error {
-	return w.cli.ContainerRestart(ctx, container, opt)
-}
-
 // Logs page with a <pre> and a Start Stream button
 func (s *Server) logsPage(w http.ResponseWriter, r *http.Request) {
 	// /logs/{id}
@@ -126,7 +137,29 @@ func (s *Server) logsPage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Server-Sent Events stream of container logs
+// logsOptionsFromQuery maps the since/until/tail query params shared by the
+// stream and download handlers onto ContainerLogsOptions. defaultTail is
+// used when the caller didn't specify a tail param: the live stream defaults
+// to a bounded "100" so the browser isn't flooded on connect, while the
+// download defaults to "all" so "download full log" actually means it.
+func logsOptionsFromQuery(q url.Values, follow bool, defaultTail string) types.ContainerLogsOptions {
+	tail := q.Get("tail")
+	if tail == "" {
+		tail = defaultTail
+	}
+	return types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+		Timestamps: true,
+		Since:      q.Get("since"),
+		Until:      q.Get("until"),
+		Tail:       tail,
+	}
+}
+
+// Server-Sent Events stream of container logs, demultiplexed via stdcopy so
+// each line is tagged with the stream it came from.
 func (s *Server) logsStreamSSE(w http.ResponseWriter, r *http.Request) {
 	// /logs/stream/{id}
 	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
@@ -137,13 +170,7 @@ func (s *Server) logsStreamSSE(w http.ResponseWriter, r *http.Request) {
 	id := parts[2]
 	ctx := r.Context()
 
-	rc, err := s.dc.Cli().ContainerLogs(ctx, id, types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-		Follow:     true,
-		Timestamps: true,
-		Tail:       "100",
-	})
+	rc, err := s.dc.ContainerLogs(ctx, id, logsOptionsFromQuery(r.URL.Query(), true, "100"))
 	if err != nil {
 		http.Error(w, err.Error(), 500)
 		return
@@ -160,28 +187,63 @@ func (s *Server) logsStreamSSE(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	buf := make([]byte, 4096)
+	stdout := &sseLineWriter{w: w, flusher: flusher, event: "stdout"}
+	stderr := &sseLineWriter{w: w, flusher: flusher, event: "stderr"}
+	if _, err := stdcopy.StdCopy(stdout, stderr, rc); err != nil && err != io.EOF {
+		log.Printf("log stream error: %v", err)
+	}
+}
+
+// logsDownload streams the full log as a plain-text attachment for offline
+// analysis. It does not follow, and does not tag stdout/stderr.
+func (s *Server) logsDownload(w http.ResponseWriter, r *http.Request) {
+	// /logs/download/{id}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "logs" || parts[1] != "download" {
+		http.NotFound(w, r)
+		return
+	}
+	id := parts[2]
+	ctx := r.Context()
+
+	rc, err := s.dc.ContainerLogs(ctx, id, logsOptionsFromQuery(r.URL.Query(), false, "all"))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".log"))
+	if _, err := stdcopy.StdCopy(w, w, rc); err != nil && err != io.EOF {
+		log.Printf("log download error: %v", err)
+	}
+}
+
+// sseLineWriter tags each complete line written to it as an SSE frame of the
+// given event type. stdcopy writes arbitrary-sized chunks, not necessarily
+// aligned on line boundaries, so partial lines are buffered until the next
+// newline arrives.
+type sseLineWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	event   string
+	pending string
+}
+
+func (s *sseLineWriter) Write(p []byte) (int, error) {
+	s.pending += string(p)
 	for {
-		n, readErr := rc.Read(buf)
-		if n > 0 {
-			chunk := string(buf[:n])
-			// Docker multiplexed logs may include headers; Docker SDK usually demuxes for ContainerLogs on recent versions.
-			// Send as SSE "data:" lines:
-			for _, line := range strings.Split(chunk, "\n") {
-				if line == "" {
-					continue
-				}
-				fmt.Fprintf(w, "data: %s\n\n", line)
-			}
-			flusher.Flush()
-		}
-		if readErr != nil {
-			if readErr != io.EOF {
-				log.Printf("log stream error: %v", readErr)
-			}
+		i := strings.IndexByte(s.pending, '\n')
+		if i < 0 {
 			break
 		}
+		line := s.pending[:i]
+		s.pending = s.pending[i+1:]
+		fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", s.event, line)
 	}
+	s.flusher.Flush()
+	return len(p), nil
 }
 
 func intPtr(i int) *int { return &i }
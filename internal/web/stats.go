@@ -0,0 +1,204 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+)
+
+// statSample is the compact payload pushed to the browser per frame and also
+// what /metrics aggregates across containers.
+type statSample struct {
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpu_percent"`
+	MemBytes   uint64  `json:"mem_bytes"`
+	NetRxBytes uint64  `json:"net_rx_bytes"`
+	NetTxBytes uint64  `json:"net_tx_bytes"`
+	BlkioRead  uint64  `json:"blkio_read_bytes"`
+	BlkioWrite uint64  `json:"blkio_write_bytes"`
+}
+
+// statsStore keeps the latest sample seen for each container so /metrics can
+// answer a scrape without needing an open SSE connection.
+type statsStore struct {
+	mu      sync.Mutex
+	samples map[string]statSample
+}
+
+func newStatsStore() *statsStore {
+	return &statsStore{samples: make(map[string]statSample)}
+}
+
+func (s *statsStore) put(id string, sample statSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples[id] = sample
+}
+
+func (s *statsStore) snapshot() map[string]statSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]statSample, len(s.samples))
+	for k, v := range s.samples {
+		out[k] = v
+	}
+	return out
+}
+
+// statsStreamSSE streams one JSON payload per Docker stats frame.
+func (s *Server) statsStreamSSE(w http.ResponseWriter, r *http.Request) {
+	// /stats/stream/{id}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "stats" || parts[1] != "stream" {
+		http.NotFound(w, r)
+		return
+	}
+	id := parts[2]
+	ctx := r.Context()
+
+	rc, err := s.dc.ContainerStats(ctx, id, true)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", 500)
+		return
+	}
+
+	dec := json.NewDecoder(rc)
+	for {
+		var raw types.StatsJSON
+		if err := dec.Decode(&raw); err != nil {
+			return
+		}
+		sample := decodeStatSample(&raw)
+		s.stats.put(id, sample)
+
+		payload, err := json.Marshal(sample)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", payload)
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+func decodeStatSample(raw *types.StatsJSON) statSample {
+	sample := statSample{Name: strings.TrimPrefix(raw.Name, "/")}
+
+	cpuDelta := float64(raw.CPUStats.CPUUsage.TotalUsage) - float64(raw.PreCPUStats.CPUUsage.TotalUsage)
+	sysDelta := float64(raw.CPUStats.SystemUsage) - float64(raw.PreCPUStats.SystemUsage)
+	if sysDelta > 0 && cpuDelta > 0 {
+		onlineCPUs := float64(raw.CPUStats.OnlineCPUs)
+		if onlineCPUs == 0 {
+			onlineCPUs = float64(len(raw.CPUStats.CPUUsage.PercpuUsage))
+		}
+		sample.CPUPercent = (cpuDelta / sysDelta) * onlineCPUs * 100
+	}
+
+	if raw.MemoryStats.Limit > 0 {
+		usage := raw.MemoryStats.Usage
+		if cache := inactiveFileBytes(raw.MemoryStats.Stats); cache < usage {
+			usage -= cache
+		}
+		sample.MemBytes = usage
+	}
+
+	for _, net := range raw.Networks {
+		sample.NetRxBytes += net.RxBytes
+		sample.NetTxBytes += net.TxBytes
+	}
+
+	for _, entry := range raw.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			sample.BlkioRead += entry.Value
+		case "write":
+			sample.BlkioWrite += entry.Value
+		}
+	}
+
+	return sample
+}
+
+// inactiveFileBytes returns the page cache bytes to subtract from raw memory
+// usage, the same way `docker stats` itself does: cgroup v1 reports it under
+// "total_inactive_file" in memory.stat, cgroup v2 (no "total_" prefix, and no
+// "cache" key at all) under "inactive_file".
+func inactiveFileBytes(memStats map[string]uint64) uint64 {
+	if v, ok := memStats["total_inactive_file"]; ok {
+		return v
+	}
+	return memStats["inactive_file"]
+}
+
+// metrics renders the latest sampled values in Prometheus exposition format.
+// Exposition requires every sample of a given metric name to form a single
+// contiguous block right after that metric's HELP/TYPE lines, so this
+// iterates metric-major (all containers for one metric, then the next
+// metric) rather than container-major.
+func (s *Server) metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	snapshot := s.stats.snapshot()
+	ids := make([]string, 0, len(snapshot))
+	for id := range snapshot {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	writeMetric(w, ids, snapshot,
+		"dockhand_container_cpu_percent", "gauge",
+		"Container CPU usage percent, last sample.",
+		func(s statSample) string { return fmt.Sprintf("%f", s.CPUPercent) })
+	writeMetric(w, ids, snapshot,
+		"dockhand_container_mem_bytes", "gauge",
+		"Container memory usage in bytes (minus cache), last sample.",
+		func(s statSample) string { return fmt.Sprintf("%d", s.MemBytes) })
+	writeMetric(w, ids, snapshot,
+		"dockhand_container_net_rx_bytes", "gauge",
+		"Container network bytes received, last sample.",
+		func(s statSample) string { return fmt.Sprintf("%d", s.NetRxBytes) })
+	writeMetric(w, ids, snapshot,
+		"dockhand_container_net_tx_bytes", "gauge",
+		"Container network bytes transmitted, last sample.",
+		func(s statSample) string { return fmt.Sprintf("%d", s.NetTxBytes) })
+	writeMetric(w, ids, snapshot,
+		"dockhand_container_blkio_read_bytes", "gauge",
+		"Container block I/O bytes read, last sample.",
+		func(s statSample) string { return fmt.Sprintf("%d", s.BlkioRead) })
+	writeMetric(w, ids, snapshot,
+		"dockhand_container_blkio_write_bytes", "gauge",
+		"Container block I/O bytes written, last sample.",
+		func(s statSample) string { return fmt.Sprintf("%d", s.BlkioWrite) })
+}
+
+// writeMetric writes one metric's HELP/TYPE header followed by its full
+// block of per-container samples, in ids order.
+func writeMetric(w http.ResponseWriter, ids []string, snapshot map[string]statSample, name, typ, help string, value func(statSample) string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+	for _, id := range ids {
+		sample := snapshot[id]
+		fmt.Fprintf(w, "%s{id=%q,name=%q} %s\n", name, id, sample.Name, value(sample))
+	}
+}